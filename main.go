@@ -7,8 +7,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/charmbracelet/bubbles/list"
@@ -17,15 +20,31 @@ import (
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+	"github.com/ledongthuc/pdf"
+	"github.com/sahilm/fuzzy"
 )
 
+// watchDebounce coalesces editor save-storms (most editors emit several
+// write/rename events per save) into a single notesChangedMsg.
+const watchDebounce = 200 * time.Millisecond
+
 // --- STYLES ---
 var (
-	appStyle    = lipgloss.NewStyle().Margin(1, 2)
-	docStyle    = lipgloss.NewStyle()
-	titleStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("62")).Bold(true)
-	promptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	helpStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	appStyle        = lipgloss.NewStyle().Margin(1, 2)
+	docStyle        = lipgloss.NewStyle()
+	titleStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("62")).Bold(true)
+	promptStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	helpStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	matchStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
+	stashStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	linkStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Underline(true)
+	activeLinkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true).Underline(true)
+)
+
+var (
+	wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]]+)\]\]`)
+	mdLinkPattern   = regexp.MustCompile(`\[([^\[\]]*)\]\(([^()]+\.md)\)`)
 )
 
 // --- STATE ---
@@ -34,24 +53,174 @@ type appState int
 const (
 	stateInitial appState = iota
 	statePromptForPath
+	stateSelectProfile
 	stateShowList
+	stateSearch
+	stateSettings
+)
+
+// profileStep tracks the small add/rename text-entry flow nested inside
+// stateSelectProfile.
+type profileStep int
+
+const (
+	profileStepNone profileStep = iota
+	profileStepAddName
+	profileStepAddPath
+	profileStepRenameName
 )
 
 // --- LIST ITEM ---
 type item struct {
 	path     string
 	noteType string // "md" or "pdf"
+	stashed  bool
 }
 
-func (i item) Title() string       { return filepath.Base(i.path) }
+func (i item) Title() string {
+	if i.stashed {
+		return stashStyle.Render("★ ") + filepath.Base(i.path)
+	}
+	return filepath.Base(i.path)
+}
 func (i item) Description() string { return i.path }
 func (i item) FilterValue() string { return filepath.Base(i.path) }
 
+// --- PROFILE LIST ITEM ---
+type profileItem struct {
+	name string
+	path string
+}
+
+func (p profileItem) Title() string       { return p.name }
+func (p profileItem) Description() string { return p.path }
+func (p profileItem) FilterValue() string { return p.name }
+
+// --- SEARCH INDEX & RESULTS ---
+
+// searchLine is one indexed, non-blank line of text pulled out of a note.
+// pdfPage is 1-based and only set for lines extracted from a PDF.
+type searchLine struct {
+	path    string
+	lineNum int
+	pdfPage int
+	text    string
+}
+
+type searchResultItem struct {
+	path    string
+	lineNum int
+	pdfPage int
+	title   string
+	snippet string
+}
+
+func (s searchResultItem) Title() string       { return s.title }
+func (s searchResultItem) Description() string { return s.snippet }
+func (s searchResultItem) FilterValue() string { return s.title }
+
+// noteLink is one [[wiki-link]] or [text](relative.md) link found in a
+// rendered note. target is the raw path/name as written in the source,
+// unresolved against notesRoot or the current file's directory.
+type noteLink struct {
+	raw    string
+	target string
+	isWiki bool
+}
+
+// parseLinks finds every wiki-link and markdown link in raw, in the order
+// they appear in the document.
+func parseLinks(raw string) []noteLink {
+	type match struct {
+		start int
+		link  noteLink
+	}
+	var matches []match
+	for _, idx := range wikiLinkPattern.FindAllStringSubmatchIndex(raw, -1) {
+		matches = append(matches, match{
+			start: idx[0],
+			link:  noteLink{raw: raw[idx[0]:idx[1]], target: raw[idx[2]:idx[3]], isWiki: true},
+		})
+	}
+	for _, idx := range mdLinkPattern.FindAllStringSubmatchIndex(raw, -1) {
+		matches = append(matches, match{
+			start: idx[0],
+			link:  noteLink{raw: raw[idx[0]:idx[1]], target: raw[idx[4]:idx[5]], isWiki: false},
+		})
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	links := make([]noteLink, len(matches))
+	for i, mt := range matches {
+		links[i] = mt.link
+	}
+	return links
+}
+
+// resolveLinkTarget turns a noteLink's raw target into a path on disk.
+// Wiki-links resolve relative to notesRoot (appending .md if missing);
+// standard markdown links resolve relative to the current file's directory.
+func resolveLinkTarget(currentFile, notesRoot string, link noteLink) string {
+	if link.isWiki {
+		target := link.target
+		if filepath.Ext(target) == "" {
+			target += ".md"
+		}
+		return filepath.Join(notesRoot, target)
+	}
+	return filepath.Join(filepath.Dir(currentFile), link.target)
+}
+
 // --- MODEL & CONFIG ---
 type Config struct {
+	SelectedProfile string              `toml:"selected_profile"`
+	Profiles        map[string]*Profile `toml:"profiles"`
+	Theme           string              `toml:"theme"`
+	WordWrap        int                 `toml:"word_wrap"`
+
+	// NotesPath is the pre-profiles config shape: a bare top-level
+	// notes_path with no profiles table. loadConfig folds it into a
+	// "default" profile via migrateLegacyConfig so upgrading doesn't
+	// silently discard an existing user's configured path.
 	NotesPath string `toml:"notes_path"`
 }
 
+// migrateLegacyConfig folds a pre-profiles config (a bare top-level
+// notes_path, no profiles table) into a single "default" profile.
+func migrateLegacyConfig(c Config) Config {
+	if len(c.Profiles) > 0 || c.NotesPath == "" {
+		return c
+	}
+	c.Profiles = map[string]*Profile{"default": {NotesPath: c.NotesPath}}
+	c.SelectedProfile = "default"
+	c.NotesPath = ""
+	return c
+}
+
+// builtinThemes are the glamour styles cycled through on the settings
+// screen; Theme may also be set to a path to a custom JSON style.
+var builtinThemes = []string{"dark", "light", "dracula", "notty"}
+
+func (c Config) theme() string {
+	if c.Theme == "" {
+		return "dark"
+	}
+	return c.Theme
+}
+
+func (c Config) wordWrap() int {
+	if c.WordWrap <= 0 {
+		return 80
+	}
+	return c.WordWrap
+}
+
+// Profile is one named notes collection, e.g. "work" or "school".
+type Profile struct {
+	NotesPath string   `toml:"notes_path"`
+	Stashed   []string `toml:"stashed"`
+}
+
 type model struct {
 	state        appState
 	list         list.Model
@@ -60,15 +229,65 @@ type model struct {
 	showViewport bool
 	config       Config
 	err          error
+	watcher      *fsnotify.Watcher
+	currentFile  string
+	notesRoot    string
+	allNotes     []item
+	stashFilter  bool
+	pdfPages     []string
+	pdfPageIndex int
+
+	profileList             list.Model
+	profileStep             profileStep
+	pendingProfileName      string
+	returnToProfileSwitcher bool
+
+	searchResultsList list.Model
+	searchIndex       []searchLine
+	searchIndexBuilt  bool
+	pendingScrollLine int
+	pendingPDFPage    int
+
+	settingsOriginalTheme    string
+	settingsOriginalWordWrap int
+	settingsPreviewRaw       string
+
+	links     []noteLink
+	linkIndex int
+	navStack  []string
 }
 
 // --- MESSAGES ---
 type (
-	configLoadedMsg   struct{ config Config }
-	notesFoundMsg     struct{ notes []item }
-	configSavedMsg    struct{}
-	fileContentMsg    string
-	errorMsg          struct{ err error }
+	configLoadedMsg struct{ config Config }
+	notesFoundMsg   struct{ notes []item }
+	configSavedMsg  struct{}
+	fileContentMsg  string
+	errorMsg        struct{ err error }
+
+	// watcherReadyMsg carries the fsnotify.Watcher once it has been set up
+	// and pointed at the selected profile's notes path.
+	watcherReadyMsg struct{ watcher *fsnotify.Watcher }
+	// notesChangedMsg is the debounced result of one or more fsnotify events
+	// under the watched notes path.
+	notesChangedMsg struct{ events []fsnotify.Event }
+	// pdfContentMsg carries the extracted text of each page of a PDF.
+	pdfContentMsg struct{ pages []string }
+	// pdfErrorMsg carries a PDF extraction failure (e.g. a scanned,
+	// image-only PDF with no extractable text). It's shown inline in the
+	// viewport rather than terminating the app, so the "o" external-open
+	// fallback stays reachable.
+	pdfErrorMsg struct {
+		err  error
+		path string
+	}
+	// searchIndexBuiltMsg carries the freshly (re)built full-text index.
+	searchIndexBuiltMsg struct{ lines []searchLine }
+	// settingsPreviewMsg carries a re-rendered settings preview pane.
+	settingsPreviewMsg string
+	// openNoteMsg requests that the viewer navigate to a resolved note path,
+	// pushing the current file onto the nav stack.
+	openNoteMsg struct{ path string }
 )
 
 func (e errorMsg) Error() string { return e.err.Error() }
@@ -86,14 +305,26 @@ func initialModel() model {
 	l.Title = "Your Notes"
 	l.SetShowHelp(true)
 
+	// Initialize profile switcher
+	pl := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	pl.Title = "Notes Collections"
+	pl.SetShowHelp(true)
+
+	// Initialize search results
+	sl := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	sl.Title = "Search Notes"
+	sl.SetShowHelp(true)
+
 	// Initialize viewport
 	vp := viewport.New(80, 24) // Default size, will be resized on WindowSizeMsg
 
 	return model{
-		state:     stateInitial,
-		textInput: ti,
-		list:      l,
-		viewport:  vp,
+		state:             stateInitial,
+		textInput:         ti,
+		list:              l,
+		profileList:       pl,
+		searchResultsList: sl,
+		viewport:          vp,
 	}
 }
 
@@ -111,6 +342,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		h, v := appStyle.GetFrameSize()
 		m.list.SetSize(msg.Width-h, msg.Height-v)
+		m.profileList.SetSize(msg.Width-h, msg.Height-v)
+		m.searchResultsList.SetSize(msg.Width-h, msg.Height-v)
 		m.viewport.Width = msg.Width - h
 		m.viewport.Height = msg.Height - v
 		return m, nil
@@ -122,25 +355,81 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// -- State-specific updates --
 	case configLoadedMsg:
-		if msg.config.NotesPath == "" {
+		m.config = msg.config
+		if len(m.config.Profiles) == 0 {
 			m.state = statePromptForPath
 			return m, nil
 		}
-		m.config = msg.config
+		if m.returnToProfileSwitcher {
+			m.returnToProfileSwitcher = false
+			m.state = stateSelectProfile
+			m.profileList.SetItems(profileListItems(m.config))
+			return m, nil
+		}
+		profile, ok := m.config.Profiles[m.config.SelectedProfile]
+		if !ok {
+			m.state = stateSelectProfile
+			m.profileList.SetItems(profileListItems(m.config))
+			return m, nil
+		}
+		if m.watcher != nil && m.notesRoot == profile.NotesPath {
+			// Same profile/path we're already watching (e.g. a config
+			// round-trip from a rename) - nothing changed underfoot, so
+			// don't tear down the watcher or rescan and reset list state.
+			m.state = stateShowList
+			return m, nil
+		}
+		if m.watcher != nil {
+			m.watcher.Close()
+			m.watcher = nil
+		}
+		m.notesRoot = profile.NotesPath
+		m.searchIndexBuilt = false
 		m.state = stateShowList
-		return m, findNotes(m.config.NotesPath)
+		return m, tea.Batch(findNotes(profile.NotesPath), watchNotes(profile.NotesPath))
 
 	case notesFoundMsg:
-		items := make([]list.Item, len(msg.notes))
-		for i, note := range msg.notes {
-			items[i] = note
-		}
-		m.list.SetItems(items)
-		return m, nil
+		m.allNotes = msg.notes
+		m = applyStashState(m)
+		return m, saveConfigQuiet(m.config)
 
 	case configSavedMsg:
 		return m, loadConfig
 
+	case watcherReadyMsg:
+		m.watcher = msg.watcher
+		return m, waitForWatcherEvent(m.watcher)
+
+	case notesChangedMsg:
+		m, cmd = applyNotesChanged(m, msg.events)
+		return m, tea.Batch(cmd, waitForWatcherEvent(m.watcher))
+
+	case searchIndexBuiltMsg:
+		m.searchIndex = msg.lines
+		m.searchIndexBuilt = true
+		m.searchResultsList.SetItems(runSearch(m.searchIndex, m.textInput.Value()))
+		return m, nil
+
+	case openNoteMsg:
+		// Dead links are a normal feature of a note graph - surface them
+		// inline rather than quitting the whole app.
+		if _, err := os.Stat(msg.path); err != nil {
+			m.viewport.SetContent(fmt.Sprintf("%s\n\n%s",
+				promptStyle.Render("Link target not found:"),
+				helpStyle.Render(msg.path)))
+			m.viewport.GotoTop()
+			return m, nil
+		}
+		if m.currentFile != "" {
+			m.navStack = append(m.navStack, m.currentFile)
+		}
+		m.currentFile = msg.path
+		noteType, _ := noteTypeForExt(filepath.Ext(msg.path))
+		if noteType == "pdf" {
+			return m, readPDFContent(msg.path)
+		}
+		return m, readMarkdownContent(msg.path)
+
 	case errorMsg:
 		m.err = msg.err
 		return m, tea.Quit
@@ -152,9 +441,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m, cmd = updatePromptView(msg, m)
 		cmds = append(cmds, cmd)
 
+	case stateSelectProfile:
+		m, cmd = updateProfileSelectView(msg, m)
+		cmds = append(cmds, cmd)
+
 	case stateShowList:
 		m, cmd = updateListView(msg, m)
 		cmds = append(cmds, cmd)
+
+	case stateSearch:
+		m, cmd = updateSearchView(msg, m)
+		cmds = append(cmds, cmd)
+
+	case stateSettings:
+		m, cmd = updateSettingsView(msg, m)
+		cmds = append(cmds, cmd)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -165,13 +466,150 @@ func updatePromptView(msg tea.Msg, m model) (model, tea.Cmd) {
 
 	if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyEnter {
 		path := m.textInput.Value()
-		return m, saveConfig(Config{NotesPath: path})
+		cfg := Config{
+			SelectedProfile: "default",
+			Profiles:        map[string]*Profile{"default": {NotesPath: path}},
+		}
+		return m, saveConfig(cfg)
 	}
 
 	m.textInput, cmd = m.textInput.Update(msg)
 	return m, cmd
 }
 
+// updateProfileSelectView drives the stateSelectProfile screen: picking a
+// profile to switch into, and the nested add/rename text-entry flow.
+func updateProfileSelectView(msg tea.Msg, m model) (model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.profileStep != profileStepNone {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			switch key.Type {
+			case tea.KeyEsc:
+				m.profileStep = profileStepNone
+				m.textInput.Reset()
+				return m, nil
+
+			case tea.KeyEnter:
+				value := strings.TrimSpace(m.textInput.Value())
+				switch m.profileStep {
+				case profileStepAddName:
+					if value == "" {
+						return m, nil
+					}
+					m.pendingProfileName = value
+					m.profileStep = profileStepAddPath
+					m.textInput.Reset()
+					m.textInput.Placeholder = "/path/to/notes"
+					return m, nil
+
+				case profileStepAddPath:
+					if m.config.Profiles == nil {
+						m.config.Profiles = map[string]*Profile{}
+					}
+					m.config.Profiles[m.pendingProfileName] = &Profile{NotesPath: value}
+					m.profileStep = profileStepNone
+					m.returnToProfileSwitcher = true
+					m.textInput.Reset()
+					return m, saveConfig(m.config)
+
+				case profileStepRenameName:
+					if value != "" && value != m.pendingProfileName {
+						if p, ok := m.config.Profiles[m.pendingProfileName]; ok {
+							delete(m.config.Profiles, m.pendingProfileName)
+							m.config.Profiles[value] = p
+							if m.config.SelectedProfile == m.pendingProfileName {
+								m.config.SelectedProfile = value
+							}
+						}
+					}
+					m.profileStep = profileStepNone
+					m.returnToProfileSwitcher = true
+					m.textInput.Reset()
+					return m, saveConfig(m.config)
+				}
+			}
+		}
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+	}
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyEnter:
+			if selected, ok := m.profileList.SelectedItem().(profileItem); ok {
+				m.config.SelectedProfile = selected.name
+				return m, saveConfig(m.config)
+			}
+			return m, nil
+		}
+
+		switch key.String() {
+		case "n":
+			m.profileStep = profileStepAddName
+			m.textInput.Reset()
+			m.textInput.Placeholder = "profile name"
+			m.textInput.Focus()
+			return m, nil
+
+		case "r":
+			if selected, ok := m.profileList.SelectedItem().(profileItem); ok {
+				m.pendingProfileName = selected.name
+				m.profileStep = profileStepRenameName
+				m.textInput.Reset()
+				m.textInput.Placeholder = "new name"
+				m.textInput.SetValue(selected.name)
+				m.textInput.Focus()
+			}
+			return m, nil
+
+		case "d":
+			if selected, ok := m.profileList.SelectedItem().(profileItem); ok {
+				delete(m.config.Profiles, selected.name)
+				if m.config.SelectedProfile == selected.name {
+					m.config.SelectedProfile = ""
+				}
+				m.returnToProfileSwitcher = true
+				return m, saveConfig(m.config)
+			}
+			return m, nil
+		}
+	}
+
+	m.profileList, cmd = m.profileList.Update(msg)
+	return m, cmd
+}
+
+// profileListItems builds the profile switcher's list items in stable,
+// alphabetical order.
+func profileListItems(cfg Config) []list.Item {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]list.Item, len(names))
+	for i, name := range names {
+		items[i] = profileItem{name: name, path: cfg.Profiles[name].NotesPath}
+	}
+	return items
+}
+
+// isPDFOpen reports whether the viewport is currently showing extracted PDF
+// pages (as opposed to rendered markdown).
+func (m model) isPDFOpen() bool {
+	return len(m.pdfPages) > 0
+}
+
+// renderPDFPage writes the current PDF page's extracted text, with a page
+// indicator, into the viewport.
+func (m *model) renderPDFPage() {
+	header := helpStyle.Render(fmt.Sprintf("Page %d/%d", m.pdfPageIndex+1, len(m.pdfPages)))
+	m.viewport.SetContent(fmt.Sprintf("%s\n\n%s", header, m.pdfPages[m.pdfPageIndex]))
+	m.viewport.GotoTop()
+}
+
 func updateListView(msg tea.Msg, m model) (model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -181,6 +619,63 @@ func updateListView(msg tea.Msg, m model) (model, tea.Cmd) {
 			switch msg.String() {
 			case "q", "esc":
 				m.showViewport = false
+			case "o":
+				if strings.ToLower(filepath.Ext(m.currentFile)) == ".pdf" {
+					return m, openPDFExternally(m.currentFile)
+				}
+			case "n":
+				if m.isPDFOpen() {
+					if m.pdfPageIndex < len(m.pdfPages)-1 {
+						m.pdfPageIndex++
+						m.renderPDFPage()
+					}
+				} else {
+					m.viewport, cmd = m.viewport.Update(msg)
+				}
+			case "p":
+				if m.isPDFOpen() {
+					if m.pdfPageIndex > 0 {
+						m.pdfPageIndex--
+						m.renderPDFPage()
+					}
+				} else {
+					m.viewport, cmd = m.viewport.Update(msg)
+				}
+			case "g":
+				if m.isPDFOpen() {
+					m.pdfPageIndex = 0
+					m.renderPDFPage()
+				} else {
+					m.viewport, cmd = m.viewport.Update(msg)
+				}
+			case "G":
+				if m.isPDFOpen() {
+					m.pdfPageIndex = len(m.pdfPages) - 1
+					m.renderPDFPage()
+				} else {
+					m.viewport, cmd = m.viewport.Update(msg)
+				}
+			case "tab":
+				if len(m.links) > 0 {
+					m.linkIndex = (m.linkIndex + 1) % len(m.links)
+				}
+			case "enter":
+				if len(m.links) > 0 {
+					link := m.links[m.linkIndex]
+					target := resolveLinkTarget(m.currentFile, m.notesRoot, link)
+					return m, func() tea.Msg { return openNoteMsg{path: target} }
+				}
+			case "backspace":
+				if len(m.navStack) > 0 {
+					prev := m.navStack[len(m.navStack)-1]
+					m.navStack = m.navStack[:len(m.navStack)-1]
+					m.currentFile = prev
+					noteType, _ := noteTypeForExt(filepath.Ext(prev))
+					if noteType == "pdf" {
+						return m, readPDFContent(prev)
+					}
+					return m, readMarkdownContent(prev)
+				}
 			default:
 				m.viewport, cmd = m.viewport.Update(msg)
 			}
@@ -191,27 +686,108 @@ func updateListView(msg tea.Msg, m model) (model, tea.Cmd) {
 			break
 		}
 
+		if msg.String() == "p" {
+			m.state = stateSelectProfile
+			m.profileList.SetItems(profileListItems(m.config))
+			return m, nil
+		}
+
+		if msg.String() == "/" {
+			m.state = stateSearch
+			m.textInput.Reset()
+			m.textInput.Placeholder = "search notes"
+			m.textInput.Focus()
+			m.searchResultsList.SetItems(nil)
+			if !m.searchIndexBuilt {
+				return m, buildSearchIndex(m.notesRoot)
+			}
+			return m, nil
+		}
+
+		if i, ok := m.list.SelectedItem().(item); ok && i.noteType == "pdf" && msg.String() == "o" {
+			return m, openPDFExternally(i.path)
+		}
+
+		if msg.String() == "s" {
+			if i, ok := m.list.SelectedItem().(item); ok {
+				m = toggleStash(m, i.path)
+				return m, saveConfigQuiet(m.config)
+			}
+			return m, nil
+		}
+
+		if msg.String() == "S" {
+			m.stashFilter = !m.stashFilter
+			m.list.SetItems(visibleItems(m))
+			return m, nil
+		}
+
+		if msg.String() == "," {
+			m.settingsOriginalTheme = m.config.Theme
+			m.settingsOriginalWordWrap = m.config.WordWrap
+			m.state = stateSettings
+			if strings.ToLower(filepath.Ext(m.currentFile)) == ".md" {
+				return m, readMarkdownContent(m.currentFile)
+			}
+			m.settingsPreviewRaw = ""
+			m.viewport.SetContent(helpStyle.Render("(select a markdown note first to preview theme changes)"))
+			return m, nil
+		}
+
 		if msg.Type == tea.KeyEnter {
 			i, ok := m.list.SelectedItem().(item)
 			if !ok {
 				return m, nil
 			}
+			m.currentFile = i.path
 			if i.noteType == "md" {
 				return m, readMarkdownContent(i.path)
 			} else if i.noteType == "pdf" {
-				return m, openPDF(i.path)
+				return m, readPDFContent(i.path)
 			}
 		}
 
 	case fileContentMsg:
 		m.showViewport = true
-		renderer, _ := glamour.NewTermRenderer(glamour.WithStylePath("dark"), glamour.WithWordWrap(m.viewport.Width))
-		str, err := renderer.Render(string(msg))
+		m.pdfPages = nil
+		m.links = parseLinks(string(msg))
+		m.linkIndex = 0
+		str, err := renderMarkdown(string(msg), m.config)
 		if err != nil {
 			m.err = err
 			return m, tea.Quit
 		}
 		m.viewport.SetContent(str)
+		if m.pendingScrollLine > 0 {
+			m.viewport.SetYOffset(mapSourceLineToRenderedOffset(m.pendingScrollLine, string(msg), str))
+			m.pendingScrollLine = 0
+		} else {
+			m.viewport.GotoTop()
+		}
+		return m, nil
+
+	case pdfContentMsg:
+		m.showViewport = true
+		m.pdfPages = msg.pages
+		m.pdfPageIndex = 0
+		m.links = nil
+		m.linkIndex = 0
+		if m.pendingPDFPage > 0 && m.pendingPDFPage-1 < len(msg.pages) {
+			m.pdfPageIndex = m.pendingPDFPage - 1
+		}
+		m.pendingPDFPage = 0
+		m.renderPDFPage()
+		return m, nil
+
+	case pdfErrorMsg:
+		m.showViewport = true
+		m.pdfPages = nil
+		m.links = nil
+		m.linkIndex = 0
+		m.pendingPDFPage = 0
+		m.viewport.SetContent(fmt.Sprintf("%s\n\n%s",
+			promptStyle.Render(fmt.Sprintf("Could not read %s:", filepath.Base(msg.path))),
+			helpStyle.Render(msg.err.Error()+"\n\n(press o to open in an external viewer)")))
 		m.viewport.GotoTop()
 		return m, nil
 	}
@@ -220,6 +796,369 @@ func updateListView(msg tea.Msg, m model) (model, tea.Cmd) {
 	return m, cmd
 }
 
+// applyNotesChanged merges a batch of debounced fsnotify events into m.list
+// in-place, so cursor position, filter and selection survive the update,
+// and re-reads the currently open markdown file if it was the one touched.
+func applyNotesChanged(m model, events []fsnotify.Event) (model, tea.Cmd) {
+	reread := false
+
+	for _, event := range events {
+		path := event.Name
+		ext := strings.ToLower(filepath.Ext(path))
+
+		switch {
+		case event.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+			if idx, ok := findItemIndex(m.list, path); ok {
+				m.list.RemoveItem(idx)
+			}
+			m.allNotes = removeNoteByPath(m.allNotes, path)
+
+		case event.Op&fsnotify.Create != 0:
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				if m.watcher != nil {
+					_ = m.watcher.Add(path)
+				}
+				continue
+			}
+			if noteType, ok := noteTypeForExt(ext); ok {
+				if _, exists := noteIndex(m.allNotes, path); !exists {
+					newItem := item{path: path, noteType: noteType}
+					m.allNotes = append(m.allNotes, newItem)
+					if !m.stashFilter {
+						m.list.InsertItem(len(m.list.Items()), newItem)
+					}
+				}
+			}
+		}
+
+		if m.showViewport && ext == ".md" && path == m.currentFile && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+			reread = true
+		}
+
+		if _, ok := noteTypeForExt(ext); ok {
+			m.searchIndexBuilt = false
+		}
+	}
+
+	if reread {
+		return m, readMarkdownContent(m.currentFile)
+	}
+	return m, nil
+}
+
+func findItemIndex(l list.Model, path string) (int, bool) {
+	for i, li := range l.Items() {
+		if it, ok := li.(item); ok && it.path == path {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func noteIndex(notes []item, path string) (int, bool) {
+	for i, n := range notes {
+		if n.path == path {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func removeNoteByPath(notes []item, path string) []item {
+	if idx, ok := noteIndex(notes, path); ok {
+		return append(notes[:idx], notes[idx+1:]...)
+	}
+	return notes
+}
+
+// visibleItems applies the stash filter (if active) to m.allNotes for
+// display in m.list.
+func visibleItems(m model) []list.Item {
+	items := make([]list.Item, 0, len(m.allNotes))
+	for _, n := range m.allNotes {
+		if m.stashFilter && !n.stashed {
+			continue
+		}
+		items = append(items, n)
+	}
+	return items
+}
+
+// applyStashState refreshes each note's stashed flag from the active
+// profile's Stashed list, dedupes it, prunes entries whose files are no
+// longer present in m.allNotes, and rebuilds m.list accordingly.
+func applyStashState(m model) model {
+	profile := m.config.Profiles[m.config.SelectedProfile]
+	if profile == nil {
+		m.list.SetItems(visibleItems(m))
+		return m
+	}
+
+	existing := make(map[string]bool, len(m.allNotes))
+	for _, n := range m.allNotes {
+		existing[n.path] = true
+	}
+
+	seen := make(map[string]bool, len(profile.Stashed))
+	pruned := make([]string, 0, len(profile.Stashed))
+	for _, p := range profile.Stashed {
+		if !existing[p] || seen[p] {
+			continue
+		}
+		seen[p] = true
+		pruned = append(pruned, p)
+	}
+	profile.Stashed = pruned
+
+	for i := range m.allNotes {
+		m.allNotes[i].stashed = seen[m.allNotes[i].path]
+	}
+	m.list.SetItems(visibleItems(m))
+	return m
+}
+
+// toggleStash pins or unpins path in the active profile's stash.
+func toggleStash(m model, path string) model {
+	profile := m.config.Profiles[m.config.SelectedProfile]
+	if profile == nil {
+		return m
+	}
+	if idx, ok := stringIndex(profile.Stashed, path); ok {
+		profile.Stashed = append(profile.Stashed[:idx], profile.Stashed[idx+1:]...)
+	} else {
+		profile.Stashed = append(profile.Stashed, path)
+	}
+	return applyStashState(m)
+}
+
+func stringIndex(ss []string, s string) (int, bool) {
+	for i, v := range ss {
+		if v == s {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func noteTypeForExt(ext string) (string, bool) {
+	switch ext {
+	case ".md":
+		return "md", true
+	case ".pdf":
+		return "pdf", true
+	default:
+		return "", false
+	}
+}
+
+// updateSearchView drives the stateSearch screen: a text input that
+// re-runs the fuzzy search on every keystroke, and a results list.
+func updateSearchView(msg tea.Msg, m model) (model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyEsc:
+			m.state = stateShowList
+			return m, nil
+
+		case tea.KeyEnter:
+			selected, ok := m.searchResultsList.SelectedItem().(searchResultItem)
+			if !ok {
+				return m, nil
+			}
+			m.currentFile = selected.path
+			m.state = stateShowList
+			if selected.pdfPage > 0 {
+				m.pendingPDFPage = selected.pdfPage
+				return m, readPDFContent(selected.path)
+			}
+			m.pendingScrollLine = selected.lineNum
+			return m, readMarkdownContent(selected.path)
+
+		case tea.KeyUp, tea.KeyDown:
+			m.searchResultsList, cmd = m.searchResultsList.Update(msg)
+			return m, cmd
+		}
+	}
+
+	prevValue := m.textInput.Value()
+	m.textInput, cmd = m.textInput.Update(msg)
+	if m.searchIndexBuilt && m.textInput.Value() != prevValue {
+		m.searchResultsList.SetItems(runSearch(m.searchIndex, m.textInput.Value()))
+	}
+	return m, cmd
+}
+
+// runSearch fuzzy-matches query against every indexed line and returns the
+// hits as list items, best match first, each with its matched span
+// highlighted in the line snippet.
+func runSearch(index []searchLine, query string) []list.Item {
+	if query == "" {
+		return nil
+	}
+
+	lines := make([]string, len(index))
+	for i, l := range index {
+		lines[i] = l.text
+	}
+
+	matches := fuzzy.Find(query, lines)
+	sort.Stable(matches)
+
+	items := make([]list.Item, len(matches))
+	for i, match := range matches {
+		l := index[match.Index]
+		loc := fmt.Sprintf("%s:%d", filepath.Base(l.path), l.lineNum)
+		if l.pdfPage > 0 {
+			loc = fmt.Sprintf("%s p.%d", filepath.Base(l.path), l.pdfPage)
+		}
+		items[i] = searchResultItem{
+			path:    l.path,
+			lineNum: l.lineNum,
+			pdfPage: l.pdfPage,
+			title:   loc,
+			snippet: highlightMatch(match.Str, match.MatchedIndexes),
+		}
+	}
+	return items
+}
+
+// mapSourceLineToRenderedOffset approximates where sourceLine (1-based, as
+// indexed in buildSearchIndex against the raw file) ends up in glamour's
+// rendered output, by scaling proportionally against each side's line
+// count. Glamour rewraps and adds decoration, so this is an approximation
+// of where the match landed, not an exact line mapping.
+func mapSourceLineToRenderedOffset(sourceLine int, raw, rendered string) int {
+	srcLines := strings.Count(raw, "\n") + 1
+	renderedLines := strings.Count(rendered, "\n") + 1
+	offset := (sourceLine - 1) * renderedLines / srcLines
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// highlightMatch re-renders line with each rune at a matched index styled
+// via lipgloss, for display in the search results list.
+func highlightMatch(line string, matchedIndexes []int) string {
+	matched := make(map[int]bool, len(matchedIndexes))
+	for _, idx := range matchedIndexes {
+		matched[idx] = true
+	}
+
+	var sb strings.Builder
+	for i, r := range []rune(line) {
+		if matched[i] {
+			sb.WriteString(matchStyle.Render(string(r)))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// renderLinksBar renders the links found in the current note as a single
+// status line, with the currently selected link highlighted.
+func renderLinksBar(m model) string {
+	labels := make([]string, len(m.links))
+	for i, link := range m.links {
+		label := link.raw
+		if i == m.linkIndex {
+			labels[i] = activeLinkStyle.Render(label)
+		} else {
+			labels[i] = linkStyle.Render(label)
+		}
+	}
+	return helpStyle.Render("links: ") + strings.Join(labels, helpStyle.Render(" · ")) +
+		helpStyle.Render("  (tab: next  enter: open  backspace: back)")
+}
+
+// updateSettingsView drives the stateSettings screen: cycling theme and
+// word-wrap with a live preview, saving on enter, reverting on esc.
+func updateSettingsView(msg tea.Msg, m model) (model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case settingsPreviewMsg:
+		m.viewport.SetContent(string(msg))
+		m.viewport.GotoTop()
+		return m, nil
+
+	case fileContentMsg:
+		m.settingsPreviewRaw = string(msg)
+		str, err := renderMarkdown(m.settingsPreviewRaw, m.config)
+		if err != nil {
+			m.err = err
+			return m, tea.Quit
+		}
+		m.viewport.SetContent(str)
+		m.viewport.GotoTop()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.config.Theme = m.settingsOriginalTheme
+			m.config.WordWrap = m.settingsOriginalWordWrap
+			m.state = stateShowList
+			return m, nil
+
+		case "enter":
+			m.state = stateShowList
+			return m, saveConfigQuiet(m.config)
+
+		case "left", "h":
+			m.config.Theme = cycleTheme(m.config.theme(), -1)
+			return m, m.refreshSettingsPreview()
+
+		case "right", "l":
+			m.config.Theme = cycleTheme(m.config.theme(), 1)
+			return m, m.refreshSettingsPreview()
+
+		case "+", "=":
+			m.config.WordWrap = m.config.wordWrap() + 5
+			return m, m.refreshSettingsPreview()
+
+		case "-":
+			m.config.WordWrap = m.config.wordWrap() - 5
+			if m.config.WordWrap < 20 {
+				m.config.WordWrap = 20
+			}
+			return m, m.refreshSettingsPreview()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// refreshSettingsPreview re-renders the cached preview text with the
+// settings screen's current theme/word-wrap, without re-reading the file.
+func (m model) refreshSettingsPreview() tea.Cmd {
+	if m.settingsPreviewRaw == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		str, err := renderMarkdown(m.settingsPreviewRaw, m.config)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return settingsPreviewMsg(str)
+	}
+}
+
+func cycleTheme(current string, delta int) string {
+	idx := 0
+	for i, t := range builtinThemes {
+		if t == current {
+			idx = i
+			break
+		}
+	}
+	idx = (idx + delta + len(builtinThemes)) % len(builtinThemes)
+	return builtinThemes[idx]
+}
+
 func (m model) View() string {
 	if m.err != nil {
 		return appStyle.Render(fmt.Sprintf("Error: %s", m.err.Error()))
@@ -232,11 +1171,41 @@ func (m model) View() string {
 		help := helpStyle.Render("(press enter to save)")
 		return appStyle.Render(fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", title, prompt, m.textInput.View(), help))
 
+	case stateSelectProfile:
+		if m.profileStep != profileStepNone {
+			var prompt string
+			switch m.profileStep {
+			case profileStepAddName:
+				prompt = "New profile name:"
+			case profileStepAddPath:
+				prompt = fmt.Sprintf("Notes path for %q:", m.pendingProfileName)
+			case profileStepRenameName:
+				prompt = fmt.Sprintf("Rename %q to:", m.pendingProfileName)
+			}
+			help := helpStyle.Render("(enter to confirm, esc to cancel)")
+			return appStyle.Render(fmt.Sprintf("%s\n\n%s\n\n%s", promptStyle.Render(prompt), m.textInput.View(), help))
+		}
+		help := helpStyle.Render("n: new  r: rename  d: delete  enter: switch")
+		return appStyle.Render(fmt.Sprintf("%s\n\n%s", m.profileList.View(), help))
+
 	case stateShowList:
 		if m.showViewport {
+			if len(m.links) > 0 {
+				return docStyle.Render(fmt.Sprintf("%s\n%s", m.viewport.View(), renderLinksBar(m)))
+			}
 			return docStyle.Render(m.viewport.View())
 		}
 		return appStyle.Render(m.list.View())
+
+	case stateSearch:
+		input := promptStyle.Render("Search: ") + m.textInput.View()
+		return appStyle.Render(fmt.Sprintf("%s\n\n%s", input, m.searchResultsList.View()))
+
+	case stateSettings:
+		status := promptStyle.Render(fmt.Sprintf("theme: %s   word_wrap: %d", m.config.theme(), m.config.wordWrap()))
+		help := helpStyle.Render("←/→: theme  +/-: word wrap  enter: save  esc: cancel")
+		return appStyle.Render(fmt.Sprintf("%s\n%s\n\n%s", status, help, docStyle.Render(m.viewport.View())))
+
 	default:
 		return appStyle.Render("Initializing...")
 	}
@@ -267,29 +1236,52 @@ func loadConfig() tea.Msg {
 		return errorMsg{fmt.Errorf("failed to load config file: %w", err)}
 	}
 
+	if len(config.Profiles) == 0 && config.NotesPath != "" {
+		config = migrateLegacyConfig(config)
+		_ = writeConfig(config) // best-effort; next load sees profiles either way
+	}
+
 	return configLoadedMsg{config}
 }
 
+func writeConfig(config Config) error {
+	path, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+	configDir := filepath.Dir(path)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create config file: %w", err)
+	}
+	defer file.Close()
+
+	if err := toml.NewEncoder(file).Encode(config); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	return nil
+}
+
 func saveConfig(config Config) tea.Cmd {
 	return func() tea.Msg {
-		path, err := getConfigPath()
-		if err != nil {
+		if err := writeConfig(config); err != nil {
 			return errorMsg{err}
 		}
-		configDir := filepath.Dir(path)
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			return errorMsg{fmt.Errorf("failed to create config directory: %w", err)}
-		}
-		file, err := os.Create(path)
-		if err != nil {
-			return errorMsg{fmt.Errorf("failed to create config file: %w", err)}
-		}
-		defer file.Close()
+		return configSavedMsg{}
+	}
+}
 
-		if err := toml.NewEncoder(file).Encode(config); err != nil {
-			return errorMsg{fmt.Errorf("failed to save configuration: %w", err)}
+// saveConfigQuiet persists config without round-tripping through loadConfig,
+// so it doesn't reset list state (selection, scan results) along the way.
+func saveConfigQuiet(config Config) tea.Cmd {
+	return func() tea.Msg {
+		if err := writeConfig(config); err != nil {
+			return errorMsg{err}
 		}
-		return configSavedMsg{}
+		return nil
 	}
 }
 
@@ -317,6 +1309,16 @@ func findNotes(root string) tea.Cmd {
 	}
 }
 
+// renderMarkdown runs raw through glamour using cfg's theme and word-wrap
+// settings.
+func renderMarkdown(raw string, cfg Config) (string, error) {
+	renderer, err := glamour.NewTermRenderer(glamour.WithStylePath(cfg.theme()), glamour.WithWordWrap(cfg.wordWrap()))
+	if err != nil {
+		return "", fmt.Errorf("failed to set up renderer: %w", err)
+	}
+	return renderer.Render(raw)
+}
+
 func readMarkdownContent(path string) tea.Cmd {
 	return func() tea.Msg {
 		content, err := os.ReadFile(path)
@@ -327,7 +1329,163 @@ func readMarkdownContent(path string) tea.Cmd {
 	}
 }
 
-func openPDF(path string) tea.Cmd {
+// watchNotes sets up a recursive fsnotify watcher rooted at root and hands
+// it back to the Bubble Tea loop via watcherReadyMsg.
+func watchNotes(root string) tea.Cmd {
+	return func() tea.Msg {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to start notes watcher: %w", err)}
+		}
+		if err := addWatcherRecursive(watcher, root); err != nil {
+			watcher.Close()
+			return errorMsg{fmt.Errorf("failed to watch notes path: %w", err)}
+		}
+		return watcherReadyMsg{watcher: watcher}
+	}
+}
+
+func addWatcherRecursive(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// waitForWatcherEvent blocks until at least one fsnotify event arrives, then
+// drains and coalesces whatever follows within watchDebounce before
+// returning a single notesChangedMsg. The caller is expected to re-issue
+// this command after every notesChangedMsg to keep listening.
+func waitForWatcherEvent(watcher *fsnotify.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		var pending []fsnotify.Event
+
+		event, ok := <-watcher.Events
+		if !ok {
+			return nil
+		}
+		pending = append(pending, event)
+
+		timer := time.NewTimer(watchDebounce)
+		defer timer.Stop()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return notesChangedMsg{events: pending}
+				}
+				pending = append(pending, event)
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(watchDebounce)
+			case err, ok := <-watcher.Errors:
+				if ok {
+					return errorMsg{err}
+				}
+				return notesChangedMsg{events: pending}
+			case <-timer.C:
+				return notesChangedMsg{events: pending}
+			}
+		}
+	}
+}
+
+// readPDFContent extracts the plain text of every page of the PDF at path,
+// for display in the viewport.
+func readPDFContent(path string) tea.Cmd {
+	return func() tea.Msg {
+		pages, err := extractPDFPages(path)
+		if err != nil {
+			return pdfErrorMsg{err: err, path: path}
+		}
+		return pdfContentMsg{pages: pages}
+	}
+}
+
+// extractPDFPages pulls the plain text out of every page of the PDF at
+// path. Shared by readPDFContent and the search indexer.
+func extractPDFPages(path string) ([]string, error) {
+	f, r, err := pdf.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PDF file: %w", err)
+	}
+	defer f.Close()
+
+	pages := make([]string, 0, r.NumPage())
+	for i := 1; i <= r.NumPage(); i++ {
+		text, err := r.Page(i).GetPlainText(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract text from page %d: %w", i, err)
+		}
+		pages = append(pages, text)
+	}
+	for _, text := range pages {
+		if strings.TrimSpace(text) != "" {
+			return pages, nil
+		}
+	}
+	return nil, fmt.Errorf("no extractable text in %s", filepath.Base(path))
+}
+
+// buildSearchIndex walks root and indexes every non-blank line of markdown
+// and extracted PDF text for fuzzy search.
+func buildSearchIndex(root string) tea.Cmd {
+	return func() tea.Msg {
+		var lines []searchLine
+
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".md":
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return nil
+				}
+				for i, text := range strings.Split(string(content), "\n") {
+					if strings.TrimSpace(text) == "" {
+						continue
+					}
+					lines = append(lines, searchLine{path: path, lineNum: i + 1, text: text})
+				}
+
+			case ".pdf":
+				pages, err := extractPDFPages(path)
+				if err != nil {
+					return nil
+				}
+				for page, content := range pages {
+					for i, text := range strings.Split(content, "\n") {
+						if strings.TrimSpace(text) == "" {
+							continue
+						}
+						lines = append(lines, searchLine{path: path, lineNum: i + 1, pdfPage: page + 1, text: text})
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errorMsg{err}
+		}
+		return searchIndexBuiltMsg{lines: lines}
+	}
+}
+
+// openPDFExternally falls back to the OS's default PDF viewer, for headless
+// setups or when the in-terminal text extraction isn't good enough.
+func openPDFExternally(path string) tea.Cmd {
 	return func() tea.Msg {
 		var cmd *exec.Cmd
 		switch runtime.GOOS {
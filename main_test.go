@@ -0,0 +1,113 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []noteLink
+	}{
+		{
+			name: "no links",
+			raw:  "just some plain text",
+			want: []noteLink{},
+		},
+		{
+			name: "single wiki link",
+			raw:  "see [[other note]] for more",
+			want: []noteLink{{raw: "[[other note]]", target: "other note", isWiki: true}},
+		},
+		{
+			name: "single markdown link",
+			raw:  "see [other note](other.md) for more",
+			want: []noteLink{{raw: "[other note](other.md)", target: "other.md", isWiki: false}},
+		},
+		{
+			name: "mixed links in document order",
+			raw:  "first [a](a.md), then [[b]], then [[c]] and [d](d.md)",
+			want: []noteLink{
+				{raw: "[a](a.md)", target: "a.md", isWiki: false},
+				{raw: "[[b]]", target: "b", isWiki: true},
+				{raw: "[[c]]", target: "c", isWiki: true},
+				{raw: "[d](d.md)", target: "d.md", isWiki: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLinks(tt.raw)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLinks(%q) = %#v, want %#v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLinkTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		currentFile string
+		notesRoot   string
+		link        noteLink
+		want        string
+	}{
+		{
+			name:        "wiki link without extension",
+			currentFile: "/notes/journal/today.md",
+			notesRoot:   "/notes",
+			link:        noteLink{target: "other note", isWiki: true},
+			want:        "/notes/other note.md",
+		},
+		{
+			name:        "wiki link with explicit extension",
+			currentFile: "/notes/journal/today.md",
+			notesRoot:   "/notes",
+			link:        noteLink{target: "other.md", isWiki: true},
+			want:        "/notes/other.md",
+		},
+		{
+			name:        "markdown link resolves relative to current file's directory",
+			currentFile: "/notes/journal/today.md",
+			notesRoot:   "/notes",
+			link:        noteLink{target: "../refs/source.md", isWiki: false},
+			want:        "/notes/refs/source.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveLinkTarget(tt.currentFile, tt.notesRoot, tt.link)
+			if got != tt.want {
+				t.Errorf("resolveLinkTarget(%q, %q, %+v) = %q, want %q", tt.currentFile, tt.notesRoot, tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCycleTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		delta   int
+		want    string
+	}{
+		{name: "next from first", current: "dark", delta: 1, want: "light"},
+		{name: "next from last wraps", current: "notty", delta: 1, want: "dark"},
+		{name: "prev from first wraps", current: "dark", delta: -1, want: "notty"},
+		{name: "unknown current resets to first, then steps", current: "custom-style.json", delta: 1, want: "light"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cycleTheme(tt.current, tt.delta)
+			if got != tt.want {
+				t.Errorf("cycleTheme(%q, %d) = %q, want %q", tt.current, tt.delta, got, tt.want)
+			}
+		})
+	}
+}